@@ -0,0 +1,145 @@
+// Package lru implements a least-recently-used cache.
+//
+// Entries are kept in recency order on a doubly linked list and indexed by a
+// map from key to list node, so Get, Put and Remove all run in O(1) time. The
+// list is a small typed intrusive list local to this package rather than a
+// general-purpose list type, which avoids boxing entries in interface values.
+package lru
+
+// node is an entry of the cache and an element of its recency list.
+type node[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *node[K, V]
+}
+
+// Cache is an LRU cache holding at most a fixed number of entries.
+// The zero value is an empty cache with no capacity limit, ready to use.
+type Cache[K comparable, V any] struct {
+	capacity int
+	head     *node[K, V] // most recently used
+	tail     *node[K, V] // least recently used
+	items    map[K]*node[K, V]
+	onEvict  func(k K, v V)
+}
+
+// New returns an empty cache holding at most capacity entries.
+// A capacity of zero or less means no limit.
+func New[K comparable, V any](capacity int) *Cache[K, V] {
+	return NewWithEvict[K, V](capacity, nil)
+}
+
+// NewWithEvict is like New, and additionally calls onEvict with each entry
+// that Put evicts to stay within capacity. onEvict is not called for entries
+// deleted by Remove or replaced by Put. A nil onEvict is ignored.
+func NewWithEvict[K comparable, V any](capacity int, onEvict func(k K, v V)) *Cache[K, V] {
+	return &Cache[K, V]{
+		capacity: capacity,
+		onEvict:  onEvict,
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Cap returns the maximum number of entries in the cache, or 0 if there is no
+// limit.
+func (c *Cache[K, V]) Cap() int {
+	if c.capacity < 0 {
+		return 0
+	}
+	return c.capacity
+}
+
+// Get returns the value stored for k and marks it as most recently used.
+// The ok result is false if k is not in the cache.
+func (c *Cache[K, V]) Get(k K) (v V, ok bool) {
+	n, ok := c.items[k]
+	if !ok {
+		return v, false
+	}
+	c.moveToFront(n)
+	return n.value, true
+}
+
+// Put stores v for k and marks it as most recently used. If the cache is full
+// and k is new, the least recently used entry is evicted first. Put reports
+// whether an entry was evicted.
+func (c *Cache[K, V]) Put(k K, v V) (evicted bool) {
+	if n, ok := c.items[k]; ok {
+		n.value = v
+		c.moveToFront(n)
+		return false
+	}
+	if c.items == nil {
+		c.items = make(map[K]*node[K, V])
+	}
+	if c.capacity > 0 && len(c.items) >= c.capacity {
+		old := c.tail
+		c.unlink(old)
+		delete(c.items, old.key)
+		if c.onEvict != nil {
+			c.onEvict(old.key, old.value)
+		}
+		evicted = true
+	}
+	n := &node[K, V]{key: k, value: v}
+	c.pushFront(n)
+	c.items[k] = n
+	return evicted
+}
+
+// Remove deletes k from the cache and reports whether it was present.
+func (c *Cache[K, V]) Remove(k K) bool {
+	n, ok := c.items[k]
+	if !ok {
+		return false
+	}
+	c.unlink(n)
+	delete(c.items, k)
+	return true
+}
+
+// Keys returns the keys in the cache from most to least recently used.
+func (c *Cache[K, V]) Keys() []K {
+	keys := make([]K, 0, len(c.items))
+	for n := c.head; n != nil; n = n.next {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+func (c *Cache[K, V]) pushFront(n *node[K, V]) {
+	n.prev = nil
+	n.next = c.head
+	if c.head != nil {
+		c.head.prev = n
+	} else {
+		c.tail = n
+	}
+	c.head = n
+}
+
+func (c *Cache[K, V]) unlink(n *node[K, V]) {
+	if n.prev != nil {
+		n.prev.next = n.next
+	} else {
+		c.head = n.next
+	}
+	if n.next != nil {
+		n.next.prev = n.prev
+	} else {
+		c.tail = n.prev
+	}
+	n.prev, n.next = nil, nil
+}
+
+func (c *Cache[K, V]) moveToFront(n *node[K, V]) {
+	if c.head == n {
+		return
+	}
+	c.unlink(n)
+	c.pushFront(n)
+}
@@ -0,0 +1,127 @@
+package lru
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEviction(t *testing.T) {
+	type evictedEntry struct {
+		k string
+		v int
+	}
+	var evicted []evictedEntry
+	c := NewWithEvict[string, int](3, func(k string, v int) {
+		evicted = append(evicted, evictedEntry{k, v})
+	})
+
+	steps := []struct {
+		op      string
+		key     string
+		value   int
+		evicted bool
+		keys    []string
+	}{
+		{"put", "a", 1, false, []string{"a"}},
+		{"put", "b", 2, false, []string{"b", "a"}},
+		{"put", "c", 3, false, []string{"c", "b", "a"}},
+		{"get", "a", 0, false, []string{"a", "c", "b"}},
+		{"put", "d", 4, true, []string{"d", "a", "c"}},      // evicts b
+		{"put", "c", 30, false, []string{"c", "d", "a"}},    // update, no eviction
+		{"remove", "d", 0, false, []string{"c", "a"}},       // no callback
+		{"put", "e", 5, false, []string{"e", "c", "a"}},     // room after remove
+		{"put", "f", 6, true, []string{"f", "e", "c"}},      // evicts a
+		{"get", "zz", 0, false, []string{"f", "e", "c"}},    // miss keeps order
+		{"put", "g", 7, true, []string{"g", "f", "e"}},      // evicts c
+		{"remove", "zz", 0, false, []string{"g", "f", "e"}}, // absent
+	}
+	for i, s := range steps {
+		switch s.op {
+		case "put":
+			if got := c.Put(s.key, s.value); got != s.evicted {
+				t.Errorf("step %d: Put(%q) = %v, want %v", i, s.key, got, s.evicted)
+			}
+		case "get":
+			c.Get(s.key)
+		case "remove":
+			c.Remove(s.key)
+		}
+		if got := c.Keys(); !reflect.DeepEqual(got, s.keys) {
+			t.Fatalf("step %d: Keys() = %v, want %v", i, got, s.keys)
+		}
+		if c.Len() != len(s.keys) {
+			t.Fatalf("step %d: Len() = %d, want %d", i, c.Len(), len(s.keys))
+		}
+	}
+
+	want := []evictedEntry{{"b", 2}, {"a", 1}, {"c", 30}}
+	if !reflect.DeepEqual(evicted, want) {
+		t.Errorf("evicted = %v, want %v", evicted, want)
+	}
+}
+
+func TestHitMiss(t *testing.T) {
+	c := New[int, string](2)
+	c.Put(1, "one")
+	c.Put(2, "two")
+	c.Put(3, "three") // evicts 1
+
+	tests := []struct {
+		key   int
+		value string
+		ok    bool
+	}{
+		{1, "", false},
+		{2, "two", true},
+		{3, "three", true},
+		{4, "", false},
+	}
+	for _, tt := range tests {
+		v, ok := c.Get(tt.key)
+		if v != tt.value || ok != tt.ok {
+			t.Errorf("Get(%d) = %q, %v; want %q, %v", tt.key, v, ok, tt.value, tt.ok)
+		}
+	}
+
+	if !c.Remove(2) {
+		t.Error("Remove(2) = false, want true")
+	}
+	if c.Remove(2) {
+		t.Error("second Remove(2) = true, want false")
+	}
+	if _, ok := c.Get(2); ok {
+		t.Error("Get(2) after Remove ok = true, want false")
+	}
+}
+
+func TestUnbounded(t *testing.T) {
+	tests := []struct {
+		name string
+		c    *Cache[int, int]
+	}{
+		{"zero value", &Cache[int, int]{}},
+		{"zero capacity", New[int, int](0)},
+		{"negative capacity", New[int, int](-1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if v, ok := tt.c.Get(1); ok || v != 0 {
+				t.Errorf("Get(1) = %v, %v; want 0, false", v, ok)
+			}
+			if tt.c.Remove(1) {
+				t.Error("Remove(1) = true, want false")
+			}
+			for i := 0; i < 1000; i++ {
+				if tt.c.Put(i, i) {
+					t.Fatalf("Put(%d) evicted in an unbounded cache", i)
+				}
+			}
+			if tt.c.Len() != 1000 || tt.c.Cap() != 0 {
+				t.Errorf("Len() = %d, Cap() = %d; want 1000, 0", tt.c.Len(), tt.c.Cap())
+			}
+			if keys := tt.c.Keys(); keys[0] != 999 || keys[999] != 0 {
+				t.Errorf("Keys() runs from %d to %d, want 999 to 0", keys[0], keys[999])
+			}
+		})
+	}
+}
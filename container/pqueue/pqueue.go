@@ -0,0 +1,177 @@
+// Package pqueue implements a priority queue backed by a binary heap.
+//
+// Items are ordered by an int priority, the lowest priority being popped
+// first. Push returns a stable Handle for the queued item, which can later be
+// passed to Update to change its priority in O(log n), e.g. the decrease-key
+// step of Dijkstra's shortest-path algorithm.
+//
+// Items with equal priority are popped in the order they were pushed; Update
+// changes an item's priority but keeps its original push order for ties.
+package pqueue
+
+// Handle is a stable reference to an item held by a PriorityQueue.
+type Handle[T any] struct {
+	item     T
+	priority int
+	seq      uint64 // push order, breaks ties between equal priorities
+	index    int    // index in the heap, -1 once the item left the queue
+}
+
+// Item returns the item referenced by h.
+func (h *Handle[T]) Item() T {
+	return h.item
+}
+
+// Priority returns the current priority of the item referenced by h.
+func (h *Handle[T]) Priority() int {
+	return h.priority
+}
+
+// Queued reports whether the item referenced by h is still in the queue.
+func (h *Handle[T]) Queued() bool {
+	return h.index >= 0
+}
+
+// PriorityQueue is a min-priority queue of items of type T.
+// The zero value is an empty queue ready to use.
+type PriorityQueue[T any] struct {
+	heap []*Handle[T]
+	seq  uint64 // sequence number of the next pushed item
+}
+
+// New returns an empty priority queue.
+func New[T any]() *PriorityQueue[T] {
+	return &PriorityQueue[T]{}
+}
+
+// Len returns the number of items in the queue.
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.heap)
+}
+
+// Push adds item with the given priority to the queue and returns its handle.
+func (q *PriorityQueue[T]) Push(item T, priority int) *Handle[T] {
+	h := &Handle[T]{item: item, priority: priority, seq: q.seq, index: len(q.heap)}
+	q.seq++
+	q.heap = append(q.heap, h)
+	q.up(h.index)
+	return h
+}
+
+// Peek returns the item with the lowest priority without removing it.
+// The ok result is false if the queue is empty.
+func (q *PriorityQueue[T]) Peek() (item T, ok bool) {
+	if len(q.heap) == 0 {
+		return item, false
+	}
+	return q.heap[0].item, true
+}
+
+// Pop removes and returns the item with the lowest priority.
+// The ok result is false if the queue is empty.
+func (q *PriorityQueue[T]) Pop() (item T, ok bool) {
+	if len(q.heap) == 0 {
+		return item, false
+	}
+	h := q.heap[0]
+	q.removeAt(0)
+	return h.item, true
+}
+
+// Update changes the priority of the item referenced by h and restores the
+// heap order. It reports false if h does not belong to q or its item has
+// already been popped or removed.
+func (q *PriorityQueue[T]) Update(h *Handle[T], priority int) bool {
+	if !q.owns(h) {
+		return false
+	}
+	h.priority = priority
+	if !q.down(h.index) {
+		q.up(h.index)
+	}
+	return true
+}
+
+// Remove removes the item referenced by h from the queue. It reports false if
+// h does not belong to q or its item has already been popped or removed.
+func (q *PriorityQueue[T]) Remove(h *Handle[T]) bool {
+	if !q.owns(h) {
+		return false
+	}
+	q.removeAt(h.index)
+	return true
+}
+
+// Clear removes all items from the queue.
+func (q *PriorityQueue[T]) Clear() {
+	for _, h := range q.heap {
+		h.index = -1
+	}
+	q.heap = nil
+}
+
+func (q *PriorityQueue[T]) owns(h *Handle[T]) bool {
+	return h != nil && h.index >= 0 && h.index < len(q.heap) && q.heap[h.index] == h
+}
+
+func (q *PriorityQueue[T]) removeAt(i int) {
+	n := len(q.heap) - 1
+	h := q.heap[i]
+	if i != n {
+		q.swap(i, n)
+	}
+	q.heap[n] = nil
+	q.heap = q.heap[:n]
+	h.index = -1
+	if i != n {
+		if !q.down(i) {
+			q.up(i)
+		}
+	}
+}
+
+func (q *PriorityQueue[T]) less(i, j int) bool {
+	a, b := q.heap[i], q.heap[j]
+	if a.priority != b.priority {
+		return a.priority < b.priority
+	}
+	return a.seq < b.seq
+}
+
+func (q *PriorityQueue[T]) swap(i, j int) {
+	q.heap[i], q.heap[j] = q.heap[j], q.heap[i]
+	q.heap[i].index = i
+	q.heap[j].index = j
+}
+
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !q.less(i, p) {
+			break
+		}
+		q.swap(i, p)
+		i = p
+	}
+}
+
+// down sifts the element at i down and reports whether it moved.
+func (q *PriorityQueue[T]) down(i int) bool {
+	start, n := i, len(q.heap)
+	for {
+		l := 2*i + 1
+		if l >= n {
+			break
+		}
+		j := l
+		if r := l + 1; r < n && q.less(r, l) {
+			j = r
+		}
+		if !q.less(j, i) {
+			break
+		}
+		q.swap(i, j)
+		i = j
+	}
+	return i > start
+}
@@ -0,0 +1,195 @@
+package pqueue
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDijkstra(t *testing.T) {
+	type edge struct {
+		to, w int
+	}
+	graph := map[int][]edge{
+		0: {{1, 4}, {2, 1}},
+		1: {{3, 1}},
+		2: {{1, 2}, {3, 5}},
+		3: {{4, 3}},
+	}
+	dist := map[int]int{0: 0}
+	handles := map[int]*Handle[int]{}
+	q := New[int]()
+	handles[0] = q.Push(0, 0)
+	var order []int
+	for q.Len() > 0 {
+		u, _ := q.Pop()
+		order = append(order, u)
+		for _, e := range graph[u] {
+			d := dist[u] + e.w
+			if old, ok := dist[e.to]; ok && d >= old {
+				continue
+			}
+			dist[e.to] = d
+			if h, ok := handles[e.to]; ok && h.Queued() {
+				if !q.Update(h, d) {
+					t.Fatalf("Update(%d, %d) = false, want true", e.to, d)
+				}
+			} else {
+				handles[e.to] = q.Push(e.to, d)
+			}
+		}
+	}
+	wantDist := map[int]int{0: 0, 1: 3, 2: 1, 3: 4, 4: 7}
+	if !reflect.DeepEqual(dist, wantDist) {
+		t.Errorf("dist = %v, want %v", dist, wantDist)
+	}
+	wantOrder := []int{0, 2, 1, 3, 4}
+	if !reflect.DeepEqual(order, wantOrder) {
+		t.Errorf("pop order = %v, want %v", order, wantOrder)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(q *PriorityQueue[string], h map[string]*Handle[string])
+		want []string
+	}{
+		{
+			name: "push order",
+			run:  func(q *PriorityQueue[string], h map[string]*Handle[string]) {},
+			want: []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name: "decrease key",
+			run: func(q *PriorityQueue[string], h map[string]*Handle[string]) {
+				q.Update(h["e"], 0)
+			},
+			want: []string{"e", "a", "b", "c", "d"},
+		},
+		{
+			name: "increase key",
+			run: func(q *PriorityQueue[string], h map[string]*Handle[string]) {
+				q.Update(h["a"], 10)
+			},
+			want: []string{"b", "c", "d", "e", "a"},
+		},
+		{
+			name: "remove middle",
+			run: func(q *PriorityQueue[string], h map[string]*Handle[string]) {
+				q.Remove(h["b"])
+			},
+			want: []string{"a", "c", "d", "e"},
+		},
+		{
+			// pushing c, a, e, b, d leaves d in the last heap slot
+			name: "remove last",
+			run: func(q *PriorityQueue[string], h map[string]*Handle[string]) {
+				q.Remove(h["d"])
+			},
+			want: []string{"a", "b", "c", "e"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := New[string]()
+			h := map[string]*Handle[string]{}
+			for _, s := range []string{"c", "a", "e", "b", "d"} {
+				h[s] = q.Push(s, int(s[0]-'a')+1)
+			}
+			tt.run(q, h)
+			var got []string
+			for q.Len() > 0 {
+				p, _ := q.Peek()
+				s, ok := q.Pop()
+				if !ok || s != p {
+					t.Fatalf("Pop() = %q, %v; Peek() = %q", s, ok, p)
+				}
+				got = append(got, s)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pop order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEqualPriority(t *testing.T) {
+	q := New[string]()
+	h := map[string]*Handle[string]{}
+	for _, s := range []string{"a1", "b2", "c1", "d2", "e1", "f2", "g1"} {
+		h[s] = q.Push(s, int(s[1]-'0'))
+	}
+	q.Update(h["c1"], 2) // c1 moves among the 2s, keeping its push order
+	q.Update(h["f2"], 1)
+	want := []string{"a1", "e1", "f2", "g1", "b2", "c1", "d2"}
+	var got []string
+	for q.Len() > 0 {
+		s, _ := q.Pop()
+		got = append(got, s)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("pop order = %v, want %v", got, want)
+	}
+}
+
+func TestStaleHandle(t *testing.T) {
+	q := New[int]()
+	popped := q.Push(1, 1)
+	q.Push(2, 2)
+	q.Pop()
+
+	cleared := New[int]()
+	afterClear := cleared.Push(1, 1)
+	cleared.Clear()
+
+	other := New[int]()
+	foreign := other.Push(3, 3)
+
+	tests := []struct {
+		name string
+		q    *PriorityQueue[int]
+		h    *Handle[int]
+	}{
+		{"popped", q, popped},
+		{"cleared", cleared, afterClear},
+		{"other queue", q, foreign},
+		{"nil", q, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.q.Update(tt.h, 0) {
+				t.Error("Update() = true, want false")
+			}
+			if tt.q.Remove(tt.h) {
+				t.Error("Remove() = true, want false")
+			}
+		})
+	}
+	if q.Len() != 1 || other.Len() != 1 || foreign.Priority() != 3 {
+		t.Errorf("stale handle modified a queue")
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	tests := []struct {
+		name string
+		q    *PriorityQueue[int]
+	}{
+		{"new", New[int]()},
+		{"zero value", &PriorityQueue[int]{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if v, ok := tt.q.Peek(); ok || v != 0 {
+				t.Errorf("Peek() = %v, %v; want 0, false", v, ok)
+			}
+			if v, ok := tt.q.Pop(); ok || v != 0 {
+				t.Errorf("Pop() = %v, %v; want 0, false", v, ok)
+			}
+			tt.q.Push(7, 1)
+			if v, ok := tt.q.Pop(); !ok || v != 7 {
+				t.Errorf("Pop() = %v, %v; want 7, true", v, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Package unionfind implements a disjoint-set (union-find) structure.
+//
+// Find uses path compression and Union merges by size, so a sequence of m
+// operations on n elements runs in nearly O(m) time. Elements are added with
+// MakeSet or implicitly by Union; lookups such as Find and Connected never add
+// elements, and treat an element that has not been added as a singleton set.
+package unionfind
+
+// UnionFind partitions elements of type T into disjoint sets.
+// The zero value is an empty union-find ready to use.
+//
+// Lookups compress paths and so modify internal state: no method, including
+// Find, Connected, SetSize and Groups, is safe for concurrent use.
+type UnionFind[T comparable] struct {
+	parent map[T]T
+	size   map[T]int // set size, only meaningful for roots
+	elems  []T       // elements in the order they were added
+	count  int       // number of disjoint sets
+}
+
+// New returns an empty union-find.
+func New[T comparable]() *UnionFind[T] {
+	return &UnionFind[T]{
+		parent: make(map[T]T),
+		size:   make(map[T]int),
+	}
+}
+
+// Len returns the number of elements.
+func (u *UnionFind[T]) Len() int {
+	return len(u.elems)
+}
+
+// Count returns the number of disjoint sets.
+func (u *UnionFind[T]) Count() int {
+	return u.count
+}
+
+// Contains reports whether x has been added.
+func (u *UnionFind[T]) Contains(x T) bool {
+	_, ok := u.parent[x]
+	return ok
+}
+
+// MakeSet adds x as a singleton set. It does nothing if x already exists.
+func (u *UnionFind[T]) MakeSet(x T) {
+	if _, ok := u.parent[x]; ok {
+		return
+	}
+	if u.parent == nil {
+		u.parent = make(map[T]T)
+		u.size = make(map[T]int)
+	}
+	u.parent[x] = x
+	u.size[x] = 1
+	u.elems = append(u.elems, x)
+	u.count++
+}
+
+// Find returns the representative of the set containing x. An element that
+// has not been added is its own representative; it is not added by Find.
+func (u *UnionFind[T]) Find(x T) T {
+	if !u.Contains(x) {
+		return x
+	}
+	return u.find(x)
+}
+
+// find returns the representative of x, which must have been added.
+func (u *UnionFind[T]) find(x T) T {
+	root := x
+	for {
+		p := u.parent[root]
+		if p == root {
+			break
+		}
+		root = p
+	}
+	// path compression
+	for x != root {
+		next := u.parent[x]
+		u.parent[x] = root
+		x = next
+	}
+	return root
+}
+
+// Union merges the sets containing x and y, adding either element first if
+// needed. It reports whether the two were in different sets.
+func (u *UnionFind[T]) Union(x, y T) bool {
+	u.MakeSet(x)
+	u.MakeSet(y)
+	rx, ry := u.find(x), u.find(y)
+	if rx == ry {
+		return false
+	}
+	if u.size[rx] < u.size[ry] {
+		rx, ry = ry, rx
+	}
+	u.parent[ry] = rx
+	u.size[rx] += u.size[ry]
+	delete(u.size, ry)
+	u.count--
+	return true
+}
+
+// Connected reports whether x and y are in the same set. Elements that have
+// not been added are connected only to themselves.
+func (u *UnionFind[T]) Connected(x, y T) bool {
+	if !u.Contains(x) || !u.Contains(y) {
+		return x == y
+	}
+	return u.find(x) == u.find(y)
+}
+
+// SetSize returns the size of the set containing x, or 0 if x has not been
+// added.
+func (u *UnionFind[T]) SetSize(x T) int {
+	if !u.Contains(x) {
+		return 0
+	}
+	return u.size[u.find(x)]
+}
+
+// Groups returns the elements of every set. Groups are ordered by their
+// earliest added element, and elements within a group by insertion order.
+func (u *UnionFind[T]) Groups() [][]T {
+	groups := make([][]T, 0, u.count)
+	index := make(map[T]int, u.count)
+	for _, x := range u.elems {
+		root := u.find(x)
+		i, ok := index[root]
+		if !ok {
+			i = len(groups)
+			index[root] = i
+			groups = append(groups, make([]T, 0, u.size[root]))
+		}
+		groups[i] = append(groups[i], x)
+	}
+	return groups
+}
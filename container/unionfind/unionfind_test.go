@@ -0,0 +1,151 @@
+package unionfind
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestUnion(t *testing.T) {
+	u := New[string]()
+	for _, s := range []string{"a", "b", "c", "d", "e", "f"} {
+		u.MakeSet(s)
+	}
+	unions := []struct {
+		x, y string
+		want bool
+	}{
+		{"a", "b", true},
+		{"e", "d", true},
+		{"d", "b", true},
+		{"a", "e", false},
+		{"f", "g", true}, // g is added implicitly
+		{"g", "f", false},
+	}
+	for _, tt := range unions {
+		if got := u.Union(tt.x, tt.y); got != tt.want {
+			t.Errorf("Union(%q, %q) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+
+	if got := u.Len(); got != 7 {
+		t.Errorf("Len() = %d, want 7", got)
+	}
+	if got := u.Count(); got != 3 {
+		t.Errorf("Count() = %d, want 3", got)
+	}
+
+	connected := []struct {
+		x, y string
+		want bool
+	}{
+		{"a", "e", true},
+		{"b", "d", true},
+		{"a", "c", false},
+		{"f", "g", true},
+		{"c", "c", true},
+		{"z", "z", true},
+		{"a", "z", false},
+	}
+	for _, tt := range connected {
+		if got := u.Connected(tt.x, tt.y); got != tt.want {
+			t.Errorf("Connected(%q, %q) = %v, want %v", tt.x, tt.y, got, tt.want)
+		}
+	}
+
+	sizes := map[string]int{"a": 4, "d": 4, "c": 1, "g": 2, "z": 0}
+	for x, want := range sizes {
+		if got := u.SetSize(x); got != want {
+			t.Errorf("SetSize(%q) = %d, want %d", x, got, want)
+		}
+	}
+
+	// groups ordered by earliest added element, members by insertion order
+	want := [][]string{{"a", "b", "d", "e"}, {"c"}, {"f", "g"}}
+	if got := u.Groups(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Groups() = %v, want %v", got, want)
+	}
+
+	// lookups must not add elements
+	if got := u.Find("z"); got != "z" {
+		t.Errorf(`Find("z") = %q, want "z"`, got)
+	}
+	if u.Contains("z") || u.Len() != 7 || u.Count() != 3 {
+		t.Errorf("lookup of unknown element changed the union-find")
+	}
+	if ra, re := u.Find("a"), u.Find("e"); ra != re {
+		t.Errorf(`Find("a") = %q, Find("e") = %q, want equal`, ra, re)
+	}
+}
+
+// unionTree joins 0..n-1, n a power of two, by repeatedly uniting the roots
+// of equal-sized sets. Union by size then builds a binomial tree of height
+// log2(n), the deepest a tree can get without path compression.
+func unionTree(n int) *UnionFind[int] {
+	u := New[int]()
+	for step := 1; step < n; step *= 2 {
+		for i := 0; i+step < n; i += 2 * step {
+			u.Union(i, i+step)
+		}
+	}
+	return u
+}
+
+// depth returns the number of parent links from x to its root.
+func depth(u *UnionFind[int], x int) int {
+	d := 0
+	for u.parent[x] != x {
+		x = u.parent[x]
+		d++
+	}
+	return d
+}
+
+func TestPathCompression(t *testing.T) {
+	const n, height = 1 << 10, 10
+	u := unionTree(n)
+	if got := depth(u, n-1); got != height {
+		t.Fatalf("depth(%d) = %d before Find, want %d", n-1, got, height)
+	}
+	var path []int
+	for x := n - 1; u.parent[x] != x; x = u.parent[x] {
+		path = append(path, x)
+	}
+
+	if root := u.Find(n - 1); root != 0 {
+		t.Fatalf("Find(%d) = %d, want 0", n-1, root)
+	}
+	for _, x := range path {
+		if got := depth(u, x); got != 1 {
+			t.Errorf("depth(%d) = %d after Find, want 1", x, got)
+		}
+	}
+	if u.Count() != 1 || u.SetSize(n-1) != n {
+		t.Errorf("Count() = %d, SetSize() = %d; want 1, %d", u.Count(), u.SetSize(n-1), n)
+	}
+}
+
+func TestZeroValue(t *testing.T) {
+	var u UnionFind[int]
+	if got := u.Find(1); got != 1 || u.Len() != 0 {
+		t.Errorf("Find(1) = %d with Len() = %d, want 1 and 0", got, u.Len())
+	}
+	u.MakeSet(1)
+	u.Union(2, 3)
+	if u.Len() != 3 || u.Count() != 2 || !u.Connected(2, 3) {
+		t.Errorf("Len() = %d, Count() = %d, Connected(2, 3) = %v; want 3, 2, true",
+			u.Len(), u.Count(), u.Connected(2, 3))
+	}
+}
+
+func BenchmarkFind(b *testing.B) {
+	for _, n := range []int{1 << 17, 1 << 20} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			u := unionTree(n)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				u.Find(i % n)
+			}
+		})
+	}
+}